@@ -0,0 +1,160 @@
+package gojdbc
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertSentinel marks a query string, produced by bulkInsertQuery, as
+// addressed to a bulk insert statement rather than the bridge's SQL
+// parser. It borrows the approach lib/pq uses for pq.CopyIn.
+const bulkInsertSentinel = "gojdbc-bulk-insert "
+
+const defaultBulkBatchSize = 1000
+
+// BulkInsert prepares a batched insert into table, borrowing the pattern of
+// PreparedStatement.addBatch()/executeBatch() on the Java side. Each call
+// to the returned statement's Exec buffers one row locally; an Exec with no
+// arguments, or the batch filling up, flushes the buffered rows to the
+// bridge as a single request.
+//
+// Callers MUST flush (Exec with no arguments) before calling tx.Commit: the
+// *sql.Tx machinery only closes prepared statements — which would otherwise
+// flush any rows still buffered — after it has already sent the commit
+// itself, which would either apply the buffered rows outside the
+// transaction's atomicity guarantee or fail against an already-committed
+// transaction. To guard against that, Close returns an error instead of
+// flushing if rows are still buffered.
+func BulkInsert(tx *sql.Tx, table string, columns ...string) (*sql.Stmt, error) {
+	return tx.Prepare(bulkInsertQuery(table, columns))
+}
+
+func bulkInsertQuery(table string, columns []string) string {
+	return fmt.Sprintf("%s%s(%s)", bulkInsertSentinel, table, strings.Join(columns, ","))
+}
+
+func parseBulkInsertQuery(query string) (table string, columns []string, ok bool) {
+	if !strings.HasPrefix(query, bulkInsertSentinel) {
+		return "", nil, false
+	}
+	rest := strings.TrimPrefix(query, bulkInsertSentinel)
+	open := strings.IndexByte(rest, '(')
+	if open < 0 || !strings.HasSuffix(rest, ")") {
+		return "", nil, false
+	}
+	return rest[:open], strings.Split(rest[open+1:len(rest)-1], ","), true
+}
+
+// BatchUpdateException mirrors java.sql.BatchUpdateException: a batch can
+// partially fail, with some rows applied and others rejected by the
+// server. UpdateCounts and RowErrors are parallel to the rows passed to the
+// batch that produced them.
+type BatchUpdateException struct {
+	UpdateCounts []int64
+	RowErrors    []string
+}
+
+func (e *BatchUpdateException) Error() string {
+	failed := 0
+	for _, msg := range e.RowErrors {
+		if msg != "" {
+			failed++
+		}
+	}
+	return fmt.Sprintf("gojdbc: batch update failed for %d of %d rows", failed, len(e.RowErrors))
+}
+
+// bulkStmt implements driver.Stmt for a statement created by BulkInsert. It
+// buffers rows locally and only talks to the bridge when the batch fills
+// up or is explicitly flushed via a no-argument Exec. Closing the statement
+// does not flush.
+type bulkStmt struct {
+	conn      *Conn
+	txID      uint64
+	table     string
+	columns   []string
+	batchSize int
+	buffered  [][]interface{}
+}
+
+func newBulkStmt(conn *Conn, txID uint64, table string, columns []string) *bulkStmt {
+	batchSize := conn.dsn.batchSize
+	if batchSize <= 0 {
+		batchSize = defaultBulkBatchSize
+	}
+	return &bulkStmt{conn: conn, txID: txID, table: table, columns: columns, batchSize: batchSize}
+}
+
+// Close reports an error if rows are still buffered rather than flushing
+// them, since a flush on Close can otherwise happen after *sql.Tx has
+// already committed (see BulkInsert's doc comment). Callers must flush
+// explicitly, with a no-argument Exec, before Close or Commit.
+func (s *bulkStmt) Close() error {
+	if len(s.buffered) != 0 {
+		return fmt.Errorf("gojdbc: bulk insert into %s closed with %d row(s) still buffered: call Exec with no arguments to flush before Close or Commit", s.table, len(s.buffered))
+	}
+	return nil
+}
+
+// NumInput reports that the number of bound parameters is not fixed ahead
+// of time, the same convention pq.CopyIn uses for its copy statements.
+func (s *bulkStmt) NumInput() int {
+	return -1
+}
+
+func (s *bulkStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if len(args) == 0 {
+		return s.flush()
+	}
+	if len(args) != len(s.columns) {
+		return nil, fmt.Errorf("gojdbc: bulk insert into %s expects %d values, got %d", s.table, len(s.columns), len(args))
+	}
+
+	row := make([]interface{}, len(args))
+	for i, v := range args {
+		row[i] = v
+	}
+	s.buffered = append(s.buffered, row)
+
+	if len(s.buffered) >= s.batchSize {
+		return s.flush()
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (s *bulkStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, fmt.Errorf("gojdbc: bulk insert statements do not support Query")
+}
+
+func (s *bulkStmt) flush() (driver.Result, error) {
+	if len(s.buffered) == 0 {
+		return driver.RowsAffected(0), nil
+	}
+
+	requestID := s.conn.newRequestID()
+	req := batchExecRequest{RequestID: requestID, TxID: s.txID, Table: s.table, Columns: s.columns, Rows: s.buffered}
+	s.buffered = nil
+
+	var resp batchExecResponse
+	if err := s.conn.roundTrip(opBatchExec, req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Err != "" {
+		return nil, fmt.Errorf("gojdbc: %s", resp.Err)
+	}
+
+	var total int64
+	failed := false
+	for i, n := range resp.RowsAffected {
+		total += n
+		if i < len(resp.RowErrors) && resp.RowErrors[i] != "" {
+			failed = true
+		}
+	}
+	if failed {
+		return nil, &BatchUpdateException{UpdateCounts: resp.RowsAffected, RowErrors: resp.RowErrors}
+	}
+	return driver.RowsAffected(total), nil
+}
@@ -0,0 +1,121 @@
+package gojdbc
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestJDBCBulkInsert(t *testing.T) {
+	db, err := sql.Open("jdbc", testConnString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err = db.Exec("drop table if exists test;"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = db.Exec("create table test(Id int auto_increment primary key, Title varchar(255), Age int, Created datetime)"); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stmt, err := BulkInsert(tx, "test", "Title", "Age", "Created")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testTime := time.Now().Round(time.Second)
+	groupSize := 1000
+	for i := 0; i < groupSize; i++ {
+		if _, err := stmt.Exec(fmt.Sprintf("The %d", i), i, testTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Flush whatever is still buffered.
+	if _, err := stmt.Exec(); err != nil {
+		t.Fatal(err)
+	}
+	if err := stmt.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := db.Query("select * from test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	i := 0
+	for rows.Next() {
+		i++
+	}
+	if i != groupSize {
+		t.Fatalf("expected %d rows but got %d", groupSize, i)
+	}
+}
+
+// BenchmarkExecPerRow measures the current one-row-per-Exec insert path.
+func BenchmarkExecPerRow(b *testing.B) {
+	db, err := sql.Open("jdbc", testConnString)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	stmt, err := db.Prepare("insert into test(Title,Age,Created) values(?,?,?)")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer stmt.Close()
+
+	testTime := time.Now()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := stmt.Exec(fmt.Sprintf("The %d", i), i, testTime); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBulkInsert measures BulkInsert batching the same rows into
+// executeBatch() calls of defaultBulkBatchSize.
+func BenchmarkBulkInsert(b *testing.B) {
+	db, err := sql.Open("jdbc", testConnString)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		b.Fatal(err)
+	}
+	stmt, err := BulkInsert(tx, "test", "Title", "Age", "Created")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	testTime := time.Now()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := stmt.Exec(fmt.Sprintf("The %d", i), i, testTime); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if _, err := stmt.Exec(); err != nil {
+		b.Fatal(err)
+	}
+	b.StopTimer()
+	stmt.Close()
+	tx.Commit()
+}
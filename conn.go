@@ -0,0 +1,355 @@
+package gojdbc
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Conn is a connection to a JDBC bridge. It implements driver.Conn plus the
+// context-aware extension interfaces (driver.QueryerContext,
+// driver.ExecerContext, driver.ConnPrepareContext, driver.ConnBeginTx,
+// driver.Pinger) so that a context passed to database/sql actually
+// interrupts in-flight work on the bridge.
+type Conn struct {
+	dsn *dsn
+
+	// mu serializes requests on nc: the bridge protocol is a simple
+	// synchronous request/response exchange, so only one request may be
+	// in flight on a given socket at a time.
+	mu sync.Mutex
+	nc net.Conn
+
+	nextRequestID uint64
+
+	closed int32
+
+	// lastActivity is a UnixNano timestamp updated on every successful
+	// roundTrip, used by monitor to decide whether the socket has been
+	// idle long enough to warrant a keepalive probe.
+	lastActivity int64
+	monitorStop  chan struct{}
+	stopMonitor  sync.Once
+}
+
+func newConn(d *dsn) (*Conn, error) {
+	nc, err := net.Dial("tcp", d.addr)
+	if err != nil {
+		return nil, fmt.Errorf("gojdbc: dial %s: %v", d.addr, err)
+	}
+
+	c := &Conn{dsn: d, nc: nc, monitorStop: make(chan struct{})}
+	atomic.StoreInt64(&c.lastActivity, time.Now().UnixNano())
+
+	if d.keepAlive > 0 || d.maxLifetime > 0 {
+		go c.monitor()
+	}
+
+	return c, nil
+}
+
+// monitor proactively probes an idle connection every keepAlive interval,
+// and retires the connection once it has been open for maxLifetime,
+// marking it with driver.ErrBadConn either way so database/sql transparently
+// redials rather than handing the caller a dead socket.
+func (c *Conn) monitor() {
+	var keepAliveTick, lifetimeExpired <-chan time.Time
+
+	if c.dsn.keepAlive > 0 {
+		t := time.NewTicker(c.dsn.keepAlive)
+		defer t.Stop()
+		keepAliveTick = t.C
+	}
+	if c.dsn.maxLifetime > 0 {
+		t := time.NewTimer(c.dsn.maxLifetime)
+		defer t.Stop()
+		lifetimeExpired = t.C
+	}
+
+	for {
+		select {
+		case <-c.monitorStop:
+			return
+		case <-lifetimeExpired:
+			c.badConn(fmt.Errorf("gojdbc: connection exceeded maxLifetime %s", c.dsn.maxLifetime))
+			return
+		case <-keepAliveTick:
+			idleFor := time.Since(time.Unix(0, atomic.LoadInt64(&c.lastActivity)))
+			if idleFor < c.dsn.keepAlive {
+				continue
+			}
+			if err := c.Ping(context.Background()); err != nil {
+				c.badConn(err)
+				return
+			}
+		}
+	}
+}
+
+func (c *Conn) newRequestID() uint64 {
+	return atomic.AddUint64(&c.nextRequestID, 1)
+}
+
+// roundTrip sends req under opcode op and decodes the single response frame
+// into resp, applying the connection's readDeadline if configured.
+func (c *Conn) roundTrip(op opcode, req interface{}, resp interface{}) error {
+	if atomic.LoadInt32(&c.closed) != 0 {
+		return driver.ErrBadConn
+	}
+
+	payload, err := encodePayload(req)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dsn.readDeadline > 0 {
+		_ = c.nc.SetDeadline(time.Now().Add(c.dsn.readDeadline))
+	}
+
+	if err := writeFrame(c.nc, frame{op: op, payload: payload}); err != nil {
+		return c.badConn(err)
+	}
+
+	f, err := readFrame(c.nc)
+	if err != nil {
+		return c.badConn(err)
+	}
+
+	atomic.StoreInt64(&c.lastActivity, time.Now().UnixNano())
+	return decodePayload(f.payload, resp)
+}
+
+// badConn marks the connection broken so database/sql discards it from the
+// pool, per the driver.ErrBadConn contract. The underlying error is
+// discarded; callers always see driver.ErrBadConn, which is what tells
+// database/sql to retry on a fresh connection.
+func (c *Conn) badConn(err error) error {
+	atomic.StoreInt32(&c.closed, 1)
+	_ = c.nc.Close()
+	return driver.ErrBadConn
+}
+
+// cancel dials a short-lived side connection to the bridge and asks it to
+// abort requestID, mirroring Statement.cancel() on the Java side. The main
+// connection may still be blocked reading the original response, so this
+// cannot reuse c.nc.
+func (c *Conn) cancel(requestID uint64) {
+	side, err := net.DialTimeout("tcp", c.dsn.addr, 2*time.Second)
+	if err != nil {
+		return
+	}
+	defer side.Close()
+
+	payload, err := encodePayload(cancelRequest{RequestID: requestID})
+	if err != nil {
+		return
+	}
+	_ = side.SetDeadline(time.Now().Add(2 * time.Second))
+	_ = writeFrame(side, frame{op: opCancel, payload: payload})
+}
+
+// watchContext starts a goroutine that sends a cancel packet to the bridge
+// if ctx is done before stop is closed. It returns a function that must be
+// called once the request completes to stop the watcher, and a function
+// that reports whether ctx was the reason the request ended.
+func (c *Conn) watchContext(ctx context.Context, requestID uint64) (stop func(), timedOut func() bool) {
+	if ctx.Done() == nil {
+		return func() {}, func() bool { return false }
+	}
+
+	done := make(chan struct{})
+	var canceled int32
+	go func() {
+		select {
+		case <-ctx.Done():
+			atomic.StoreInt32(&canceled, 1)
+			c.cancel(requestID)
+			// The opCancel above is best-effort: the side-connection dial
+			// can itself fail, or the bridge can be slow to honor it, and
+			// either way roundTrip's blocking readFrame is otherwise
+			// governed solely by the DSN's readDeadline, not by ctx. Force
+			// it to unblock locally as a backstop so a canceled or
+			// expired ctx is a hard bound in practice, not just a
+			// best-effort request to the bridge. SetDeadline is documented
+			// safe to call while a Read is already in flight.
+			_ = c.nc.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	return func() { close(done) }, func() bool { return atomic.LoadInt32(&canceled) != 0 }
+}
+
+func (c *Conn) Prepare(query string) (driver.Stmt, error) {
+	return c.prepare(context.Background(), 0, query)
+}
+
+// PrepareContext implements driver.ConnPrepareContext.
+func (c *Conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.prepare(ctx, 0, query)
+}
+
+func (c *Conn) prepare(ctx context.Context, txID uint64, query string) (driver.Stmt, error) {
+	if table, columns, ok := parseBulkInsertQuery(query); ok {
+		return newBulkStmt(c, txID, table, columns), nil
+	}
+
+	rewritten := c.dsn.dialect.RewriteQuery(query)
+
+	requestID := c.newRequestID()
+	stop, timedOut := c.watchContext(ctx, requestID)
+	defer stop()
+
+	var resp prepareResponse
+	err := c.roundTrip(opPrepare, prepareRequest{TxID: txID, Query: rewritten}, &resp)
+	if err != nil {
+		if timedOut() {
+			return nil, ctxErr(ctx)
+		}
+		return nil, err
+	}
+	if resp.Err != "" {
+		return nil, errors.New(resp.Err)
+	}
+
+	return &Stmt{conn: c, id: resp.StmtID, query: rewritten, numInput: resp.NumInput, txID: txID, dialect: c.dsn.dialect}, nil
+}
+
+func (c *Conn) Close() error {
+	atomic.StoreInt32(&c.closed, 1)
+	c.stopMonitor.Do(func() { close(c.monitorStop) })
+	return c.nc.Close()
+}
+
+func (c *Conn) Begin() (driver.Tx, error) {
+	return c.beginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx implements driver.ConnBeginTx.
+func (c *Conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.beginTx(ctx, opts)
+}
+
+func (c *Conn) beginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	requestID := c.newRequestID()
+	stop, timedOut := c.watchContext(ctx, requestID)
+	defer stop()
+
+	var resp txRequest
+	if err := c.roundTrip(opBeginTx, struct{ RequestID uint64 }{requestID}, &resp); err != nil {
+		if timedOut() {
+			return nil, ctxErr(ctx)
+		}
+		return nil, err
+	}
+	return &Tx{conn: c, id: resp.TxID}, nil
+}
+
+// Exec implements the (deprecated) driver.Execer, used as a fallback by
+// database/sql for drivers that also implement ExecerContext.
+func (c *Conn) Exec(query string, args []driver.Value) (driver.Result, error) {
+	return c.exec(context.Background(), 0, query, valuesToNamed(args))
+}
+
+// ExecContext implements driver.ExecerContext.
+func (c *Conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.exec(ctx, 0, query, args)
+}
+
+func (c *Conn) exec(ctx context.Context, txID uint64, query string, args []driver.NamedValue) (driver.Result, error) {
+	stmt, err := c.prepare(ctx, txID, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	s, ok := stmt.(*Stmt)
+	if !ok {
+		return nil, fmt.Errorf("gojdbc: %q is a bulk insert sentinel query and must be executed via BulkInsert, not Exec", query)
+	}
+	return s.exec(ctx, args)
+}
+
+// Query implements the (deprecated) driver.Queryer fallback.
+func (c *Conn) Query(query string, args []driver.Value) (driver.Rows, error) {
+	return c.query(context.Background(), 0, query, valuesToNamed(args))
+}
+
+// QueryContext implements driver.QueryerContext.
+func (c *Conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.query(ctx, 0, query, args)
+}
+
+func (c *Conn) query(ctx context.Context, txID uint64, query string, args []driver.NamedValue) (driver.Rows, error) {
+	stmt, err := c.prepare(ctx, txID, query)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := stmt.(*Stmt)
+	if !ok {
+		_ = stmt.Close()
+		return nil, fmt.Errorf("gojdbc: %q is a bulk insert sentinel query and must be executed via BulkInsert, not Query", query)
+	}
+	rows, err := s.doQuery(ctx, args)
+	// The bridge streams rows independently of the prepared statement, so
+	// the statement handle itself can be released immediately.
+	_ = stmt.Close()
+	return rows, err
+}
+
+// Ping implements driver.Pinger. A context deadline or cancellation sends a
+// cancel packet for the ping request so it doesn't block database/sql's
+// connection health checks indefinitely.
+func (c *Conn) Ping(ctx context.Context) error {
+	requestID := c.newRequestID()
+	stop, timedOut := c.watchContext(ctx, requestID)
+	defer stop()
+
+	var resp struct{ Err string }
+	if err := c.roundTrip(opPing, pingRequest{RequestID: requestID}, &resp); err != nil {
+		if timedOut() {
+			return ctxErr(ctx)
+		}
+		return err
+	}
+	if resp.Err != "" {
+		return errors.New(resp.Err)
+	}
+	return nil
+}
+
+// withQueryTimeout derives a context bounded by the DSN's queryTimeout,
+// mirroring Statement.setQueryTimeout() on the Java side. It only applies
+// the bound when the caller hasn't already imposed a tighter or looser one
+// of their own via context.
+func (c *Conn) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.dsn.queryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.dsn.queryTimeout)
+}
+
+func ctxErr(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return context.Canceled
+}
+
+func valuesToNamed(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
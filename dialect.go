@@ -0,0 +1,143 @@
+package gojdbc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Dialect adapts the driver's canonical `?`-placeholder query syntax and
+// generated-key handling to a specific JDBC-backed database, selected via
+// the jdbc DSN's dialect= parameter (defaulting to "mysql").
+type Dialect interface {
+	// Name identifies the dialect, matching the DSN's dialect= value.
+	Name() string
+	// RewriteQuery translates a query written with `?` placeholders into
+	// the dialect's native placeholder syntax before it is sent to the
+	// bridge. Named parameters bound via sql.Named travel alongside the
+	// query as (name, value) pairs rather than through query rewriting,
+	// so a query written with native named placeholders (e.g. Oracle's
+	// `:id`) can be passed through unchanged.
+	RewriteQuery(query string) string
+	// LastInsertID extracts the generated key from a completed Exec,
+	// since JDBC's getGeneratedKeys() is not uniform across vendors.
+	LastInsertID(resp execResponse) (int64, error)
+}
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[string]Dialect{
+		"mysql":    mysqlDialect{},
+		"postgres": postgresDialect{},
+		"oracle":   oracleDialect{},
+		"mssql":    mssqlDialect{},
+		"sqlite":   sqliteDialect{},
+	}
+)
+
+// RegisterDialect makes a Dialect available for selection via the jdbc
+// DSN's dialect= parameter. It follows the convention of
+// database/sql.Register: call it from an init function, and it panics if
+// name is already registered.
+func RegisterDialect(name string, d Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+	if _, dup := dialects[name]; dup {
+		panic("gojdbc: RegisterDialect called twice for dialect " + name)
+	}
+	dialects[name] = d
+}
+
+func lookupDialect(name string) (Dialect, error) {
+	if name == "" {
+		name = "mysql"
+	}
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+	d, ok := dialects[name]
+	if !ok {
+		return nil, fmt.Errorf("gojdbc: unknown dialect %q", name)
+	}
+	return d, nil
+}
+
+// rewritePlaceholders replaces each top-level `?` in query (i.e. one not
+// inside a '...' or "..." literal) with placeholder(i), where i is the
+// placeholder's 1-based position.
+func rewritePlaceholders(query string, placeholder func(i int) string) string {
+	var b strings.Builder
+	n := 0
+	inSingle, inDouble := false, false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' && !inDouble:
+			inSingle = !inSingle
+			b.WriteByte(c)
+		case c == '"' && !inSingle:
+			inDouble = !inDouble
+			b.WriteByte(c)
+		case c == '?' && !inSingle && !inDouble:
+			n++
+			b.WriteString(placeholder(n))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string                 { return "mysql" }
+func (mysqlDialect) RewriteQuery(q string) string { return q }
+func (mysqlDialect) LastInsertID(resp execResponse) (int64, error) {
+	return resp.LastInsertID, nil
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return "postgres" }
+func (postgresDialect) RewriteQuery(q string) string {
+	return rewritePlaceholders(q, func(i int) string { return fmt.Sprintf("$%d", i) })
+}
+func (postgresDialect) LastInsertID(resp execResponse) (int64, error) {
+	if resp.LastInsertID == 0 {
+		return 0, errors.New(`gojdbc: postgres only returns a generated key when the query has a RETURNING clause, e.g. "... RETURNING id"`)
+	}
+	return resp.LastInsertID, nil
+}
+
+type oracleDialect struct{}
+
+func (oracleDialect) Name() string { return "oracle" }
+func (oracleDialect) RewriteQuery(q string) string {
+	return rewritePlaceholders(q, func(i int) string { return fmt.Sprintf(":%d", i) })
+}
+func (oracleDialect) LastInsertID(resp execResponse) (int64, error) {
+	if resp.LastInsertID == 0 {
+		return 0, errors.New(`gojdbc: oracle only returns a generated key when the query has a RETURNING ... INTO clause`)
+	}
+	return resp.LastInsertID, nil
+}
+
+type mssqlDialect struct{}
+
+func (mssqlDialect) Name() string { return "mssql" }
+func (mssqlDialect) RewriteQuery(q string) string {
+	return rewritePlaceholders(q, func(i int) string { return fmt.Sprintf("@p%d", i) })
+}
+func (mssqlDialect) LastInsertID(resp execResponse) (int64, error) {
+	// The bridge runs "SELECT SCOPE_IDENTITY()" immediately after the
+	// insert and reports it back as the generated key.
+	return resp.LastInsertID, nil
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string                 { return "sqlite" }
+func (sqliteDialect) RewriteQuery(q string) string { return q }
+func (sqliteDialect) LastInsertID(resp execResponse) (int64, error) {
+	return resp.LastInsertID, nil
+}
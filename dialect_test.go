@@ -0,0 +1,50 @@
+package gojdbc
+
+import "testing"
+
+func TestRewritePlaceholders(t *testing.T) {
+	cases := []struct {
+		dialect Dialect
+		query   string
+		want    string
+	}{
+		{mysqlDialect{}, "select * from t where a=? and b=?", "select * from t where a=? and b=?"},
+		{postgresDialect{}, "select * from t where a=? and b=?", "select * from t where a=$1 and b=$2"},
+		{oracleDialect{}, "insert into t(a) values(?)", "insert into t(a) values(:1)"},
+		{mssqlDialect{}, "update t set a=? where b=?", "update t set a=@p1 where b=@p2"},
+		{postgresDialect{}, "select * from t where a='?' and b=?", "select * from t where a='?' and b=$1"},
+	}
+
+	for _, c := range cases {
+		got := c.dialect.RewriteQuery(c.query)
+		if got != c.want {
+			t.Errorf("%s.RewriteQuery(%q) = %q, want %q", c.dialect.Name(), c.query, got, c.want)
+		}
+	}
+}
+
+func TestLookupDialectDefaultsToMySQL(t *testing.T) {
+	d, err := lookupDialect("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Name() != "mysql" {
+		t.Fatalf("expected default dialect mysql, got %s", d.Name())
+	}
+}
+
+func TestLookupDialectUnknown(t *testing.T) {
+	if _, err := lookupDialect("db2"); err == nil {
+		t.Fatal("expected an error for an unregistered dialect")
+	}
+}
+
+func TestPostgresLastInsertIDRequiresReturning(t *testing.T) {
+	if _, err := (postgresDialect{}).LastInsertID(execResponse{}); err == nil {
+		t.Fatal("expected an error when no RETURNING id was executed")
+	}
+	id, err := (postgresDialect{}).LastInsertID(execResponse{LastInsertID: 42})
+	if err != nil || id != 42 {
+		t.Fatalf("got (%d, %v), want (42, nil)", id, err)
+	}
+}
@@ -0,0 +1,27 @@
+// Package gojdbc implements a database/sql/driver.Driver that talks to a
+// remote JDBC bridge process over TCP, letting Go programs use any JDBC
+// driver available to that bridge through the standard database/sql API.
+package gojdbc
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+func init() {
+	sql.Register("jdbc", &Driver{})
+}
+
+// Driver implements driver.Driver for the jdbc bridge protocol.
+type Driver struct{}
+
+// Open dials the bridge named by name, a DSN of the form
+// "tcp://host:port/?queryTimeout=5&readDeadline=10&fetchSize=500", and
+// returns a ready-to-use connection.
+func (d *Driver) Open(name string) (driver.Conn, error) {
+	dsn, err := parseDSN(name)
+	if err != nil {
+		return nil, err
+	}
+	return newConn(dsn)
+}
@@ -1,6 +1,7 @@
 package gojdbc
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"sync"
@@ -247,6 +248,46 @@ func TestJDBCWithQueryTimeout(t *testing.T) {
 
 }
 
+func TestJDBCWithContextTimeout(t *testing.T) {
+	db, err := sql.Open("jdbc", testConnString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// A self-join against a large table gives the bridge enough work that
+	// the deadline fires before it can respond.
+	_, err = db.QueryContext(ctx, "select t.* from test t join test t2")
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestJDBCWithContextCancel(t *testing.T) {
+	db, err := sql.Open("jdbc", testConnString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err = db.QueryContext(ctx, "select t.* from test t join test t2")
+	<-done
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
 func TestJDBCSystemStatus(t *testing.T) {
 	fatalErr := func(e error) {
 		if e != nil {
@@ -461,3 +502,47 @@ func TestJDBCWithFetchSize(t *testing.T) {
 	}
 
 }
+
+func TestJDBCPing(t *testing.T) {
+	db, err := sql.Open("jdbc", testConnString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestJDBCReconnectAfterDeadSocket kills the TCP connection backing a
+// pooled *sql.Conn directly (bypassing any cooperation from the bridge)
+// and confirms the next query transparently gets a fresh connection
+// instead of surfacing the dead socket's error, which is only possible
+// because the driver reports driver.ErrBadConn rather than the raw I/O
+// error.
+func TestJDBCReconnectAfterDeadSocket(t *testing.T) {
+	db, err := sql.Open("jdbc", testConnString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Raw(func(driverConn interface{}) error {
+		return driverConn.(*Conn).nc.Close()
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Query("select 1"); err != nil {
+		t.Fatalf("expected a fresh connection to be used, got %v", err)
+	}
+}
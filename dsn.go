@@ -0,0 +1,94 @@
+package gojdbc
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DSN parameter names recognized on a jdbc connection string, e.g.
+// "tcp://host:port/?queryTimeout=5&readDeadline=10&fetchSize=500".
+const (
+	paramQueryTimeout = "queryTimeout"
+	paramReadDeadline = "readDeadline"
+	paramFetchSize    = "fetchSize"
+	paramBatchSize    = "batchSize"
+	paramDialect      = "dialect"
+	paramKeepAlive    = "keepAlive"
+	paramMaxLifetime  = "maxLifetime"
+)
+
+// dsn holds the parsed form of a jdbc connection string.
+type dsn struct {
+	addr         string
+	queryTimeout time.Duration
+	readDeadline time.Duration
+	fetchSize    int
+	batchSize    int
+	dialect      Dialect
+	keepAlive    time.Duration
+	maxLifetime  time.Duration
+}
+
+func parseDSN(name string) (*dsn, error) {
+	u, err := url.Parse(name)
+	if err != nil {
+		return nil, fmt.Errorf("gojdbc: invalid dsn %q: %v", name, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("gojdbc: dsn %q is missing a host", name)
+	}
+
+	d := &dsn{addr: u.Host}
+
+	q := u.Query()
+	if v := q.Get(paramQueryTimeout); v != "" {
+		secs, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("gojdbc: invalid %s %q: %v", paramQueryTimeout, v, err)
+		}
+		d.queryTimeout = time.Duration(secs) * time.Second
+	}
+	if v := q.Get(paramReadDeadline); v != "" {
+		secs, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("gojdbc: invalid %s %q: %v", paramReadDeadline, v, err)
+		}
+		d.readDeadline = time.Duration(secs) * time.Second
+	}
+	if v := q.Get(paramFetchSize); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("gojdbc: invalid %s %q: %v", paramFetchSize, v, err)
+		}
+		d.fetchSize = n
+	}
+	if v := q.Get(paramBatchSize); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("gojdbc: invalid %s %q: %v", paramBatchSize, v, err)
+		}
+		d.batchSize = n
+	}
+
+	d.dialect, err = lookupDialect(q.Get(paramDialect))
+	if err != nil {
+		return nil, err
+	}
+
+	if v := q.Get(paramKeepAlive); v != "" {
+		d.keepAlive, err = time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("gojdbc: invalid %s %q: %v", paramKeepAlive, v, err)
+		}
+	}
+	if v := q.Get(paramMaxLifetime); v != "" {
+		d.maxLifetime, err = time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("gojdbc: invalid %s %q: %v", paramMaxLifetime, v, err)
+		}
+	}
+
+	return d, nil
+}
@@ -0,0 +1,349 @@
+package gojdbc
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ListenerEventType describes a state transition reported to a Listener's
+// event callback, mirroring pq.ListenerEventType.
+type ListenerEventType int
+
+const (
+	// ListenerEventConnected is sent when the listener establishes its
+	// first connection to the bridge.
+	ListenerEventConnected ListenerEventType = iota
+	// ListenerEventDisconnected is sent when an established connection is
+	// lost, with the error that caused the loss.
+	ListenerEventDisconnected
+	// ListenerEventReconnected is sent when the listener re-establishes a
+	// connection after ListenerEventDisconnected.
+	ListenerEventReconnected
+	// ListenerEventConnectionAttemptFailed is sent when a reconnect attempt
+	// itself fails, with the DSN or dial error. A failed initial connection
+	// is returned by NewListener instead.
+	ListenerEventConnectionAttemptFailed
+)
+
+var errListenerClosed = errors.New("gojdbc: listener closed")
+
+// Notification is a single asynchronous event delivered on a Listener's
+// Notify channel: a Postgres LISTEN/NOTIFY payload, an Oracle DBMS_AQ
+// message, or a MySQL binlog event, however the bridge's JDBC notification
+// extension for the underlying database represents it.
+type Notification struct {
+	Channel string
+	Payload string
+}
+
+// Listener opens a dedicated connection to a jdbc bridge and delivers
+// asynchronous notifications over Notify, analogous to pq.Listener. It
+// reconnects with exponential backoff between minReconnectInterval and
+// maxReconnectInterval, replays the set of channels currently being
+// listened to after every reconnect, and reports connection state changes
+// through eventCallback (which may be nil).
+type Listener struct {
+	name                 string
+	minReconnectInterval time.Duration
+	maxReconnectInterval time.Duration
+	eventCallback        func(ListenerEventType, error)
+
+	// Notify receives one *Notification per event delivered by the
+	// bridge. It buffers 64 notifications; while it is full, further
+	// notifications are dropped. It is closed once Close has fully torn the
+	// listener down.
+	Notify chan *Notification
+
+	mu       sync.Mutex
+	channels map[string]bool
+	active   *listenerConn
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	done      chan struct{}
+}
+
+// NewListener dials name (a jdbc DSN) and starts delivering notifications
+// on the returned Listener's Notify channel. The initial connection
+// attempt happens synchronously so callers can fail fast on a bad DSN;
+// subsequent reconnects happen in the background.
+func NewListener(name string, minReconnectInterval, maxReconnectInterval time.Duration, eventCallback func(ListenerEventType, error)) (*Listener, error) {
+	d, err := parseDSN(name)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Listener{
+		name:                 name,
+		minReconnectInterval: minReconnectInterval,
+		maxReconnectInterval: maxReconnectInterval,
+		eventCallback:        eventCallback,
+		Notify:               make(chan *Notification, 64),
+		channels:             make(map[string]bool),
+		closeCh:              make(chan struct{}),
+		done:                 make(chan struct{}),
+	}
+
+	lc, err := dialListenerConn(d)
+	if err != nil {
+		return nil, err
+	}
+	l.setActive(lc)
+	l.emit(ListenerEventConnected, nil)
+
+	go l.manage(lc)
+	return l, nil
+}
+
+func (l *Listener) emit(event ListenerEventType, err error) {
+	if l.eventCallback != nil {
+		l.eventCallback(event, err)
+	}
+}
+
+func (l *Listener) setActive(lc *listenerConn) {
+	l.mu.Lock()
+	l.active = lc
+	l.mu.Unlock()
+}
+
+// manage owns the reconnect loop; lc is the already-established initial
+// connection.
+func (l *Listener) manage(lc *listenerConn) {
+	defer close(l.done)
+	defer close(l.Notify)
+
+	backoff := l.minReconnectInterval
+	for {
+		err := lc.run(l.Notify)
+		l.setActive(nil)
+
+		select {
+		case <-l.closeCh:
+			return
+		default:
+		}
+		l.emit(ListenerEventDisconnected, err)
+
+		for {
+			select {
+			case <-l.closeCh:
+				return
+			case <-time.After(backoff):
+			}
+
+			d, dsnErr := parseDSN(l.name)
+			if dsnErr != nil {
+				l.emit(ListenerEventConnectionAttemptFailed, dsnErr)
+				backoff = nextBackoff(backoff, l.maxReconnectInterval)
+				continue
+			}
+
+			next, dialErr := dialListenerConn(d)
+			if dialErr != nil {
+				l.emit(ListenerEventConnectionAttemptFailed, dialErr)
+				backoff = nextBackoff(backoff, l.maxReconnectInterval)
+				continue
+			}
+
+			l.mu.Lock()
+			replay := make([]string, 0, len(l.channels))
+			for ch := range l.channels {
+				replay = append(replay, ch)
+			}
+			l.mu.Unlock()
+
+			lc = next
+			l.setActive(lc)
+			l.emit(ListenerEventReconnected, nil)
+			backoff = l.minReconnectInterval
+			// The acks these replayed LISTENs wait for are dispatched by
+			// next.run, started at the top of the outer loop.
+			go func() {
+				for _, ch := range replay {
+					_ = next.sendListen(ch)
+				}
+			}()
+			break
+		}
+	}
+}
+
+func nextBackoff(cur, max time.Duration) time.Duration {
+	cur *= 2
+	if cur > max {
+		cur = max
+	}
+	return cur
+}
+
+// Listen subscribes to channel, replaying it automatically on every future
+// reconnect until Unlisten is called.
+func (l *Listener) Listen(channel string) error {
+	l.mu.Lock()
+	l.channels[channel] = true
+	lc := l.active
+	l.mu.Unlock()
+
+	if lc == nil {
+		return nil
+	}
+	return lc.sendListen(channel)
+}
+
+// Unlisten cancels a subscription started by Listen.
+func (l *Listener) Unlisten(channel string) error {
+	l.mu.Lock()
+	delete(l.channels, channel)
+	lc := l.active
+	l.mu.Unlock()
+
+	if lc == nil {
+		return nil
+	}
+	return lc.sendUnlisten(channel)
+}
+
+// Close tears the listener down: no further reconnect attempts are made,
+// the underlying connection is closed, and Notify is closed once the
+// background goroutine has exited.
+func (l *Listener) Close() error {
+	l.closeOnce.Do(func() { close(l.closeCh) })
+
+	l.mu.Lock()
+	lc := l.active
+	l.mu.Unlock()
+	if lc != nil {
+		lc.close()
+	}
+
+	<-l.done
+	return nil
+}
+
+// listenerConn is the dedicated connection a Listener holds open, with a
+// background read loop that both dispatches acks for in-flight
+// Listen/Unlisten requests and pushes unsolicited notification frames.
+type listenerConn struct {
+	nc net.Conn
+
+	writeMu sync.Mutex
+	nextID  uint64
+
+	pendingMu sync.Mutex
+	pending   map[uint64]chan listenAck
+
+	closeOnce sync.Once
+}
+
+func dialListenerConn(d *dsn) (*listenerConn, error) {
+	nc, err := net.Dial("tcp", d.addr)
+	if err != nil {
+		return nil, err
+	}
+	return &listenerConn{nc: nc, pending: make(map[uint64]chan listenAck)}, nil
+}
+
+func (lc *listenerConn) close() {
+	lc.closeOnce.Do(func() { lc.nc.Close() })
+}
+
+func (lc *listenerConn) newRequestID() uint64 {
+	return atomic.AddUint64(&lc.nextID, 1)
+}
+
+func (lc *listenerConn) send(op opcode, channel string) (uint64, chan listenAck, error) {
+	id := lc.newRequestID()
+	ackCh := make(chan listenAck, 1)
+
+	lc.pendingMu.Lock()
+	lc.pending[id] = ackCh
+	lc.pendingMu.Unlock()
+
+	payload, err := encodePayload(listenRequest{RequestID: id, Channel: channel})
+	if err != nil {
+		return id, ackCh, err
+	}
+
+	lc.writeMu.Lock()
+	err = writeFrame(lc.nc, frame{op: op, payload: payload})
+	lc.writeMu.Unlock()
+	return id, ackCh, err
+}
+
+func (lc *listenerConn) sendListen(channel string) error {
+	_, ackCh, err := lc.send(opListen, channel)
+	if err != nil {
+		return err
+	}
+	ack := <-ackCh
+	if ack.Err != "" {
+		return errors.New(ack.Err)
+	}
+	return nil
+}
+
+func (lc *listenerConn) sendUnlisten(channel string) error {
+	_, ackCh, err := lc.send(opUnlisten, channel)
+	if err != nil {
+		return err
+	}
+	ack := <-ackCh
+	if ack.Err != "" {
+		return errors.New(ack.Err)
+	}
+	return nil
+}
+
+// run reads frames until the connection is closed or fails, dispatching
+// notification pushes to notifyCh and acks to their waiting sender. It
+// returns the error that ended the loop.
+func (lc *listenerConn) run(notifyCh chan<- *Notification) error {
+	for {
+		f, err := readFrame(lc.nc)
+		if err != nil {
+			lc.failPending(err)
+			return err
+		}
+
+		switch f.op {
+		case opNotification:
+			var n notificationFrame
+			if decodePayload(f.payload, &n) != nil {
+				continue
+			}
+			select {
+			case notifyCh <- &Notification{Channel: n.Channel, Payload: n.Payload}:
+			default:
+				// Slow consumer: drop rather than block the read loop and
+				// starve the ack dispatch below.
+			}
+		default:
+			var ack listenAck
+			if decodePayload(f.payload, &ack) != nil {
+				continue
+			}
+			lc.pendingMu.Lock()
+			ch, ok := lc.pending[ack.RequestID]
+			if ok {
+				delete(lc.pending, ack.RequestID)
+			}
+			lc.pendingMu.Unlock()
+			if ok {
+				ch <- ack
+			}
+		}
+	}
+}
+
+func (lc *listenerConn) failPending(err error) {
+	lc.pendingMu.Lock()
+	defer lc.pendingMu.Unlock()
+	for id, ch := range lc.pending {
+		ch <- listenAck{RequestID: id, Err: err.Error()}
+		delete(lc.pending, id)
+	}
+}
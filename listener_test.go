@@ -0,0 +1,56 @@
+package gojdbc
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestNextBackoff(t *testing.T) {
+	min := 100 * time.Millisecond
+	max := 1 * time.Second
+
+	backoff := min
+	for i := 0; i < 10; i++ {
+		backoff = nextBackoff(backoff, max)
+		if backoff > max {
+			t.Fatalf("backoff %v exceeded max %v", backoff, max)
+		}
+	}
+	if backoff != max {
+		t.Fatalf("expected backoff to settle at max %v, got %v", max, backoff)
+	}
+}
+
+func TestJDBCListener(t *testing.T) {
+	var events []ListenerEventType
+	l, err := NewListener(testConnString, 10*time.Millisecond, time.Second, func(e ListenerEventType, err error) {
+		events = append(events, e)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err := l.Listen("test_channel"); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("jdbc", testConnString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("notify test_channel, 'hello'"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case n := <-l.Notify:
+		if n.Channel != "test_channel" || n.Payload != "hello" {
+			t.Fatalf("unexpected notification: %+v", n)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+}
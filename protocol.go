@@ -0,0 +1,212 @@
+package gojdbc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"time"
+)
+
+func init() {
+	// argValue.Value and fetchResponse.Rows carry driver.Value as a bare
+	// interface{}, and gob requires every non-builtin concrete type that
+	// crosses an interface boundary to be registered up front. Of
+	// driver.Value's permitted types (nil, int64, float64, bool, []byte,
+	// string, time.Time), only time.Time isn't one of gob's built-in cases.
+	gob.Register(time.Time{})
+}
+
+// opcode identifies the kind of request/response exchanged with the JDBC
+// bridge over the wire.
+type opcode byte
+
+const (
+	opPing opcode = iota + 1
+	opPrepare
+	opExec
+	opQuery
+	opFetch
+	opCloseStmt
+	opCloseRows
+	opBeginTx
+	opCommit
+	opRollback
+	opCancel
+	opStatus
+	opBatchExec
+	opListen
+	opUnlisten
+	opNotification
+)
+
+// frame is the unit of exchange with the bridge: a 4-byte big-endian length
+// prefix (covering the opcode byte and payload), followed by the opcode and
+// a gob-encoded payload.
+type frame struct {
+	op      opcode
+	payload []byte
+}
+
+func writeFrame(w io.Writer, f frame) error {
+	buf := make([]byte, 5+len(f.payload))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(f.payload)+1))
+	buf[4] = byte(f.op)
+	copy(buf[5:], f.payload)
+	_, err := w.Write(buf)
+	return err
+}
+
+func readFrame(r io.Reader) (frame, error) {
+	var hdr [4]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return frame{}, err
+	}
+	n := binary.BigEndian.Uint32(hdr[:])
+	if n == 0 {
+		return frame{}, fmt.Errorf("gojdbc: empty frame")
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return frame{}, err
+	}
+	return frame{op: opcode(body[0]), payload: body[1:]}, nil
+}
+
+func encodePayload(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, fmt.Errorf("gojdbc: encode payload: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodePayload(payload []byte, v interface{}) error {
+	if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(v); err != nil {
+		return fmt.Errorf("gojdbc: decode payload: %v", err)
+	}
+	return nil
+}
+
+// execRequest asks the bridge to run a statement (query or DML) with bound
+// parameters. requestID lets a later opCancel frame identify which
+// in-flight request to interrupt.
+type execRequest struct {
+	RequestID uint64
+	StmtID    uint64
+	TxID      uint64
+	Args      []argValue
+}
+
+// argValue carries a bound parameter's name alongside its value so the
+// bridge can bind by name (sql.Named) as well as by position.
+type argValue struct {
+	Name  string
+	Value interface{}
+}
+
+// execResponse carries either the updated row count and generated key for a
+// DML statement, or the columns of a result set that follows in subsequent
+// opFetch frames.
+type execResponse struct {
+	Err          string
+	RowsAffected int64
+	LastInsertID int64
+	Columns      []columnMeta
+	RowsID       uint64
+}
+
+// JDBC's java.sql.ResultSetMetaData.isNullable() nullability codes.
+const (
+	columnNoNulls         = 0
+	columnNullable        = 1
+	columnNullableUnknown = 2
+)
+
+// columnMeta mirrors the subset of java.sql.ResultSetMetaData the driver
+// surfaces through sql.Rows.ColumnTypes(): its SQL type name, precision and
+// scale, nullability, and the Go type Scan will populate.
+type columnMeta struct {
+	Name         string
+	DatabaseType string // e.g. "VARCHAR", "INT", "TIMESTAMP"
+	ScanType     string // key into the scanTypeByName table: "int64", "string", ...
+	Nullable     int
+	Precision    int64
+	Scale        int64
+}
+
+type prepareRequest struct {
+	TxID  uint64
+	Query string
+}
+
+type prepareResponse struct {
+	Err      string
+	StmtID   uint64
+	NumInput int
+}
+
+type fetchRequest struct {
+	RequestID uint64
+	RowsID    uint64
+	Count     int
+}
+
+type fetchResponse struct {
+	Err  string
+	Rows [][]interface{}
+	Done bool
+}
+
+type cancelRequest struct {
+	RequestID uint64
+}
+
+type txRequest struct {
+	TxID uint64
+}
+
+type pingRequest struct {
+	RequestID uint64
+}
+
+// batchExecRequest asks the bridge to run PreparedStatement.addBatch() for
+// each row and then executeBatch(), mapping to a single wire round trip
+// regardless of how many rows are buffered.
+type batchExecRequest struct {
+	RequestID uint64
+	TxID      uint64
+	Table     string
+	Columns   []string
+	Rows      [][]interface{}
+}
+
+// batchExecResponse mirrors java.sql.BatchUpdateException: RowErrors has
+// one entry per row in the request (empty string on success), and
+// RowsAffected has the per-row update count, parallel to RowErrors.
+type batchExecResponse struct {
+	Err          string
+	RowsAffected []int64
+	RowErrors    []string
+}
+
+// listenRequest asks the bridge to subscribe (opListen) or unsubscribe
+// (opUnlisten) the connection to a notification channel. The bridge
+// replies with an ack frame carrying the same opcode and RequestID.
+type listenRequest struct {
+	RequestID uint64
+	Channel   string
+}
+
+type listenAck struct {
+	RequestID uint64
+	Err       string
+}
+
+// notificationFrame is pushed unsolicited by the bridge, at any time, on a
+// connection with one or more active LISTEN channels.
+type notificationFrame struct {
+	Channel string
+	Payload string
+}
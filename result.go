@@ -0,0 +1,19 @@
+package gojdbc
+
+// Result implements driver.Result for a completed Exec.
+type Result struct {
+	rowsAffected  int64
+	lastInsertID  int64
+	lastInsertErr error
+}
+
+func (r *Result) LastInsertId() (int64, error) {
+	if r.lastInsertErr != nil {
+		return 0, r.lastInsertErr
+	}
+	return r.lastInsertID, nil
+}
+
+func (r *Result) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
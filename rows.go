@@ -0,0 +1,186 @@
+package gojdbc
+
+import (
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const defaultFetchSize = 200
+
+// fetchResult is one batch handed from the background prefetch goroutine
+// to Next.
+type fetchResult struct {
+	rows [][]interface{}
+	done bool
+	err  error
+}
+
+// Rows is a forward-only, server-side cursor over a result set returned by
+// the bridge, matching ResultSet.setFetchSize()/cursor semantics on the
+// Java side. While the caller processes the current batch of rows, a
+// background goroutine fetches the next batch ahead of time, so memory use
+// stays bounded by fetchSize regardless of how large the result set is.
+type Rows struct {
+	conn      *Conn
+	id        uint64
+	columns   []columnMeta
+	fetchSize int
+
+	buf  [][]interface{}
+	pos  int
+	done bool
+
+	results chan fetchResult
+	stop    chan struct{}
+	stopped sync.Once
+}
+
+func newRows(conn *Conn, id uint64, columns []columnMeta, fetchSize int) *Rows {
+	if fetchSize <= 0 {
+		fetchSize = defaultFetchSize
+	}
+	r := &Rows{
+		conn:      conn,
+		id:        id,
+		columns:   columns,
+		fetchSize: fetchSize,
+		results:   make(chan fetchResult, 1),
+		stop:      make(chan struct{}),
+	}
+	go r.prefetch()
+	return r
+}
+
+// prefetch runs on its own goroutine for the lifetime of the cursor,
+// issuing the next "fetch next N" request as soon as the previous batch
+// has been queued on results (which holds one batch), pipelining bridge
+// round trips with the caller's processing of the current batch.
+func (r *Rows) prefetch() {
+	for {
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+
+		rows, done, err := r.fetchBatch()
+		select {
+		case r.results <- fetchResult{rows: rows, done: done, err: err}:
+		case <-r.stop:
+			return
+		}
+		if done || err != nil {
+			return
+		}
+	}
+}
+
+func (r *Rows) fetchBatch() ([][]interface{}, bool, error) {
+	requestID := r.conn.newRequestID()
+	req := fetchRequest{RequestID: requestID, RowsID: r.id, Count: r.fetchSize}
+	var resp fetchResponse
+	if err := r.conn.roundTrip(opFetch, req, &resp); err != nil {
+		return nil, true, err
+	}
+	if resp.Err != "" {
+		return nil, true, errors.New(resp.Err)
+	}
+	return resp.Rows, resp.Done, nil
+}
+
+func (r *Rows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, c := range r.columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func (r *Rows) Close() error {
+	r.stopped.Do(func() { close(r.stop) })
+	var resp struct{ Err string }
+	if err := r.conn.roundTrip(opCloseRows, struct{ RowsID uint64 }{r.id}, &resp); err != nil {
+		return err
+	}
+	if resp.Err != "" {
+		return errors.New(resp.Err)
+	}
+	return nil
+}
+
+func (r *Rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.buf) {
+		if r.done {
+			return io.EOF
+		}
+		res := <-r.results
+		if res.err != nil {
+			r.done = true
+			return res.err
+		}
+		r.buf = res.rows
+		r.pos = 0
+		r.done = res.done
+		if len(r.buf) == 0 {
+			return io.EOF
+		}
+	}
+
+	row := r.buf[r.pos]
+	r.pos++
+	for i, v := range row {
+		dest[i] = v
+	}
+	return nil
+}
+
+// ColumnTypeScanType implements driver.RowsColumnTypeScanType.
+func (r *Rows) ColumnTypeScanType(index int) reflect.Type {
+	return scanType(r.columns[index].ScanType)
+}
+
+// ColumnTypeDatabaseTypeName implements driver.RowsColumnTypeDatabaseTypeName.
+func (r *Rows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.columns[index].DatabaseType
+}
+
+// ColumnTypeNullable implements driver.RowsColumnTypeNullable.
+func (r *Rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	switch r.columns[index].Nullable {
+	case columnNullable:
+		return true, true
+	case columnNoNulls:
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// ColumnTypePrecisionScale implements driver.RowsColumnTypePrecisionScale.
+func (r *Rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	c := r.columns[index]
+	if c.Precision == 0 {
+		return 0, 0, false
+	}
+	return c.Precision, c.Scale, true
+}
+
+var scanTypeByName = map[string]reflect.Type{
+	"int64":     reflect.TypeOf(int64(0)),
+	"float64":   reflect.TypeOf(float64(0)),
+	"bool":      reflect.TypeOf(false),
+	"string":    reflect.TypeOf(""),
+	"[]byte":    reflect.TypeOf([]byte(nil)),
+	"time.Time": reflect.TypeOf(time.Time{}),
+}
+
+func scanType(name string) reflect.Type {
+	if t, ok := scanTypeByName[name]; ok {
+		return t
+	}
+	return reflect.TypeOf((*interface{})(nil)).Elem()
+}
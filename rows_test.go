@@ -0,0 +1,48 @@
+package gojdbc
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScanType(t *testing.T) {
+	cases := map[string]reflect.Type{
+		"int64":     reflect.TypeOf(int64(0)),
+		"string":    reflect.TypeOf(""),
+		"time.Time": reflect.TypeOf(time.Time{}),
+		"unknown":   reflect.TypeOf((*interface{})(nil)).Elem(),
+	}
+	for name, want := range cases {
+		if got := scanType(name); got != want {
+			t.Errorf("scanType(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+// BenchmarkStreamingRows1M selects a million-row table and drains it with
+// rows.Next(), reporting allocations to demonstrate that memory use stays
+// bounded by fetchSize rather than growing with the result-set size.
+func BenchmarkStreamingRows1M(b *testing.B) {
+	db, err := sql.Open("jdbc", fmt.Sprintf("%s?%s=%d", testConnString, paramFetchSize, 500))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		rows, err := db.Query("select * from big_test")
+		if err != nil {
+			b.Fatal(err)
+		}
+		count := 0
+		for rows.Next() {
+			count++
+		}
+		rows.Close()
+	}
+}
@@ -0,0 +1,57 @@
+package gojdbc
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// Status reports the bridge's view of its own health, as returned by
+// ServerStatus.
+type Status struct {
+	ActiveConnections int
+	ActiveStatements  int
+	UptimeSeconds     int64
+	BridgeVersion     string
+}
+
+// ServerStatus opens a short-lived connection to the bridge named by dsn
+// and returns its current status. It does not go through database/sql, so
+// it can be called without first opening a pooled *sql.DB.
+func ServerStatus(name string) (Status, error) {
+	d, err := parseDSN(name)
+	if err != nil {
+		return Status{}, err
+	}
+
+	nc, err := net.DialTimeout("tcp", d.addr, 5*time.Second)
+	if err != nil {
+		return Status{}, fmt.Errorf("gojdbc: dial %s: %v", d.addr, err)
+	}
+	defer nc.Close()
+
+	payload, err := encodePayload(struct{}{})
+	if err != nil {
+		return Status{}, err
+	}
+	if err := writeFrame(nc, frame{op: opStatus, payload: payload}); err != nil {
+		return Status{}, fmt.Errorf("gojdbc: status request: %v", err)
+	}
+
+	f, err := readFrame(nc)
+	if err != nil {
+		return Status{}, fmt.Errorf("gojdbc: status response: %v", err)
+	}
+
+	var resp struct {
+		Err    string
+		Status Status
+	}
+	if err := decodePayload(f.payload, &resp); err != nil {
+		return Status{}, err
+	}
+	if resp.Err != "" {
+		return Status{}, fmt.Errorf("gojdbc: %s", resp.Err)
+	}
+	return resp.Status, nil
+}
@@ -0,0 +1,108 @@
+package gojdbc
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+// Stmt is a prepared statement handle on the bridge. It implements
+// driver.Stmt plus driver.StmtExecContext and driver.StmtQueryContext so
+// that a context passed to *sql.Stmt.ExecContext/QueryContext can interrupt
+// the call on the bridge.
+type Stmt struct {
+	conn     *Conn
+	id       uint64
+	txID     uint64
+	query    string
+	numInput int
+	dialect  Dialect
+}
+
+func (s *Stmt) Close() error {
+	var resp struct{ Err string }
+	if err := s.conn.roundTrip(opCloseStmt, struct{ StmtID uint64 }{s.id}, &resp); err != nil {
+		return err
+	}
+	if resp.Err != "" {
+		return errors.New(resp.Err)
+	}
+	return nil
+}
+
+func (s *Stmt) NumInput() int {
+	return s.numInput
+}
+
+func (s *Stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.exec(context.Background(), valuesToNamed(args))
+}
+
+// ExecContext implements driver.StmtExecContext.
+func (s *Stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.exec(ctx, args)
+}
+
+func (s *Stmt) exec(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, cancel := s.conn.withQueryTimeout(ctx)
+	defer cancel()
+
+	requestID := s.conn.newRequestID()
+	stop, timedOut := s.conn.watchContext(ctx, requestID)
+	defer stop()
+
+	req := execRequest{RequestID: requestID, StmtID: s.id, TxID: s.txID, Args: namedToArgValues(args)}
+	var resp execResponse
+	if err := s.conn.roundTrip(opExec, req, &resp); err != nil {
+		if timedOut() {
+			return nil, ctxErr(ctx)
+		}
+		return nil, err
+	}
+	if resp.Err != "" {
+		return nil, errors.New(resp.Err)
+	}
+	lastInsertID, lastInsertErr := s.dialect.LastInsertID(resp)
+	return &Result{rowsAffected: resp.RowsAffected, lastInsertID: lastInsertID, lastInsertErr: lastInsertErr}, nil
+}
+
+func (s *Stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.doQuery(context.Background(), valuesToNamed(args))
+}
+
+// QueryContext implements driver.StmtQueryContext.
+func (s *Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.doQuery(ctx, args)
+}
+
+// doQuery is named to avoid colliding with the query field above (a field
+// and a method can't share a name).
+func (s *Stmt) doQuery(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, cancel := s.conn.withQueryTimeout(ctx)
+	defer cancel()
+
+	requestID := s.conn.newRequestID()
+	stop, timedOut := s.conn.watchContext(ctx, requestID)
+	defer stop()
+
+	req := execRequest{RequestID: requestID, StmtID: s.id, TxID: s.txID, Args: namedToArgValues(args)}
+	var resp execResponse
+	if err := s.conn.roundTrip(opQuery, req, &resp); err != nil {
+		if timedOut() {
+			return nil, ctxErr(ctx)
+		}
+		return nil, err
+	}
+	if resp.Err != "" {
+		return nil, errors.New(resp.Err)
+	}
+	return newRows(s.conn, resp.RowsID, resp.Columns, s.conn.dsn.fetchSize), nil
+}
+
+func namedToArgValues(args []driver.NamedValue) []argValue {
+	values := make([]argValue, len(args))
+	for i, a := range args {
+		values[i] = argValue{Name: a.Name, Value: a.Value}
+	}
+	return values
+}
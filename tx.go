@@ -0,0 +1,31 @@
+package gojdbc
+
+import "errors"
+
+// Tx is a transaction handle on the bridge, implementing driver.Tx.
+type Tx struct {
+	conn *Conn
+	id   uint64
+}
+
+func (tx *Tx) Commit() error {
+	var resp struct{ Err string }
+	if err := tx.conn.roundTrip(opCommit, txRequest{TxID: tx.id}, &resp); err != nil {
+		return err
+	}
+	if resp.Err != "" {
+		return errors.New(resp.Err)
+	}
+	return nil
+}
+
+func (tx *Tx) Rollback() error {
+	var resp struct{ Err string }
+	if err := tx.conn.roundTrip(opRollback, txRequest{TxID: tx.id}, &resp); err != nil {
+		return err
+	}
+	if resp.Err != "" {
+		return errors.New(resp.Err)
+	}
+	return nil
+}